@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.flipt.io/flipt/internal/analytics"
+	"go.flipt.io/flipt/internal/telemetry"
+)
+
+func init() {
+	rootCmd.AddCommand(newTelemetryCommand())
+}
+
+func newTelemetryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Manage Flipt's anonymized telemetry reporting",
+	}
+
+	cmd.AddCommand(newTelemetryPreviewCommand())
+
+	return cmd
+}
+
+func newTelemetryPreviewCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "preview",
+		Short: "Print the exact JSON payload telemetry reporting would send",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			logger := loggerFromConfig(cfg)
+
+			store, err := newStoreFromConfig(cmd.Context(), logger, cfg)
+			if err != nil {
+				return err
+			}
+
+			sink, err := analytics.New(logger, cfg, false)
+			if err != nil {
+				return err
+			}
+			defer sink.Close()
+
+			reporter, err := telemetry.NewReporter(logger, cfg, store, sink, cfg.Storage.Local.Path)
+			if err != nil {
+				return err
+			}
+
+			snapshot, err := reporter.Build(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("building telemetry preview: %w", err)
+			}
+
+			out, err := json.MarshalIndent(snapshot, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+			return nil
+		},
+	}
+}
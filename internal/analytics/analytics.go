@@ -0,0 +1,24 @@
+package analytics
+
+import (
+	"fmt"
+
+	"go.flipt.io/flipt/internal/analytics/prometheus"
+	"go.flipt.io/flipt/internal/config"
+	"go.flipt.io/flipt/internal/server/analytics/clickhouse"
+	"go.uber.org/zap"
+)
+
+// New constructs the configured analytics Sink. Operators who already run Prometheus can select
+// it via config.Analytics.Backend so they don't have to stand up Clickhouse just to power the
+// analytics UI.
+func New(logger *zap.Logger, cfg *config.Config, forceMigrate bool) (Sink, error) {
+	switch cfg.Analytics.Backend {
+	case config.AnalyticsBackendPrometheus:
+		return prometheus.New(logger, cfg)
+	case config.AnalyticsBackendClickhouse, "":
+		return clickhouse.New(logger, cfg, forceMigrate)
+	default:
+		return nil, fmt.Errorf("analytics: unknown backend %q", cfg.Analytics.Backend)
+	}
+}
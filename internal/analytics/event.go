@@ -0,0 +1,30 @@
+package analytics
+
+import "time"
+
+// FlagType distinguishes the two kinds of flags an EvaluationEvent can be recorded for.
+type FlagType string
+
+const (
+	FlagTypeVariant FlagType = "variant"
+	FlagTypeBoolean FlagType = "boolean"
+)
+
+// EvaluationEvent carries the full outcome of a single flag evaluation, so that sinks can answer
+// richer questions than a raw count: what fraction of evaluations matched, how traffic splits
+// across variants, and how evaluation latency is distributed.
+//
+// EntityID is the raw requesting entity identifier. Sinks must never persist it as-is; it exists
+// here only so a sink can derive a bounded-cardinality hash (e.g. via siphash) at write time.
+type EvaluationEvent struct {
+	Timestamp    time.Time
+	NamespaceKey string
+	FlagKey      string
+	FlagType     FlagType
+	Match        bool
+	Reason       string
+	VariantKey   string
+	SegmentKeys  []string
+	EntityID     string
+	Duration     time.Duration
+}
@@ -0,0 +1,192 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	internalanalytics "go.flipt.io/flipt/internal/analytics"
+	"go.flipt.io/flipt/internal/config"
+	"go.flipt.io/flipt/rpc/flipt/analytics"
+	"go.uber.org/zap"
+)
+
+const (
+	metricName = "flipt_flag_evaluation_count_total"
+	timeFormat = "2006-01-02 15:04:05"
+)
+
+// Client is a Sink backed by a Prometheus counter for writes and the Prometheus HTTP API for
+// reads. It lets operators who already run Prometheus power the analytics UI without standing up
+// a separate Clickhouse instance.
+type Client struct {
+	logger *zap.Logger
+
+	queryURL string
+	http     *http.Client
+
+	evaluations *prometheus.CounterVec
+	durations   *prometheus.HistogramVec
+}
+
+// New constructs a Client that registers the flag evaluation counter against the default
+// Prometheus registry and queries cfg.Analytics.Prometheus.URL to satisfy reads.
+func New(logger *zap.Logger, cfg *config.Config) (*Client, error) {
+	if cfg.Analytics.Prometheus.URL == "" {
+		return nil, fmt.Errorf("analytics: prometheus backend requires Analytics.Prometheus.URL")
+	}
+
+	return &Client{
+		logger:   logger,
+		queryURL: cfg.Analytics.Prometheus.URL,
+		http:     &http.Client{Timeout: 10 * time.Second},
+		evaluations: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: metricName,
+			Help: "The total number of times a flag has been evaluated.",
+		}, []string{"namespace", "flag", "variant", "match"}),
+		durations: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "flipt_flag_evaluation_duration_seconds",
+			Help:    "The duration of flag evaluations.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"namespace", "flag"}),
+	}, nil
+}
+
+// IncrementFlagEvaluation increments the flipt_flag_evaluation_count_total counter for the given
+// namespace and flag. The variant and match labels are left empty here; they are populated once
+// callers evaluate through RecordEvaluation, which carries the full evaluation outcome.
+func (c *Client) IncrementFlagEvaluation(_ context.Context, namespaceKey, flagKey string) error {
+	c.evaluations.WithLabelValues(namespaceKey, flagKey, "", "").Inc()
+	return nil
+}
+
+// RecordEvaluation increments flipt_flag_evaluation_count_total with the full set of labels and
+// observes the evaluation's duration.
+func (c *Client) RecordEvaluation(_ context.Context, event internalanalytics.EvaluationEvent) error {
+	c.evaluations.WithLabelValues(event.NamespaceKey, event.FlagKey, event.VariantKey, strconv.FormatBool(event.Match)).Inc()
+	c.durations.WithLabelValues(event.NamespaceKey, event.FlagKey).Observe(event.Duration.Seconds())
+	return nil
+}
+
+// GetFlagEvaluationsCount satisfies reads by querying the Prometheus HTTP API's query_range
+// endpoint for sum(rate(flipt_flag_evaluation_count_total[...])) over the requested window.
+func (c *Client) GetFlagEvaluationsCount(ctx context.Context, req *analytics.GetFlagEvaluationsCountRequest) ([]string, []float32, error) {
+	fromTime, err := time.Parse(timeFormat, req.From)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toTime, err := time.Parse(timeFormat, req.To)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	step := stepFromDuration(toTime.Sub(fromTime))
+
+	query := fmt.Sprintf(
+		`sum(rate(%s{namespace=%q, flag=%q}[%s]))`,
+		metricName, req.NamespaceKey, req.FlagKey, step,
+	)
+
+	values := url.Values{}
+	values.Set("query", query)
+	values.Set("start", strconv.FormatInt(fromTime.Unix(), 10))
+	values.Set("end", strconv.FormatInt(toTime.Unix(), 10))
+	values.Set("step", step)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.queryURL+"/api/v1/query_range?"+values.Encode(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("prometheus: query_range returned status %d", resp.StatusCode)
+	}
+
+	var result queryRangeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, nil, err
+	}
+
+	timestamps := make([]string, 0)
+	counts := make([]float32, 0)
+
+	if len(result.Data.Result) == 0 {
+		return timestamps, counts, nil
+	}
+
+	for _, sample := range result.Data.Result[0].Values {
+		ts, value, err := sample.parse()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		timestamps = append(timestamps, ts.UTC().Format(timeFormat))
+		counts = append(counts, value)
+	}
+
+	return timestamps, counts, nil
+}
+
+// Close is a no-op: the counter is registered against the global registry for the lifetime of
+// the process and there is nothing buffered to flush.
+func (c *Client) Close() error {
+	return nil
+}
+
+// stepFromDuration translates the window the client asked for into a Prometheus duration-string
+// step, mirroring the interval steps the Clickhouse backend uses for the same windows.
+func stepFromDuration(d time.Duration) string {
+	switch {
+	case d <= time.Hour:
+		return "15s"
+	case d <= 4*time.Hour:
+		return "1m"
+	default:
+		return "15m"
+	}
+}
+
+type queryRangeResponse struct {
+	Data struct {
+		Result []struct {
+			Values []sample `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// sample is a single [unixSeconds, "value"] pair as returned by the Prometheus HTTP API.
+type sample [2]any
+
+func (s sample) parse() (time.Time, float32, error) {
+	sec, ok := s[0].(float64)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("prometheus: unexpected sample timestamp %v", s[0])
+	}
+
+	str, ok := s[1].(string)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("prometheus: unexpected sample value %v", s[1])
+	}
+
+	value, err := strconv.ParseFloat(str, 32)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return time.Unix(int64(sec), 0), float32(value), nil
+}
@@ -0,0 +1,52 @@
+package analytics
+
+import (
+	"context"
+
+	"go.flipt.io/flipt/rpc/flipt/analytics"
+)
+
+// Sink is implemented by every analytics backend Flipt can be configured to write flag
+// evaluations to and query flag evaluation counts from.
+type Sink interface {
+	// IncrementFlagEvaluation records that a flag was evaluated. Implementations are free to
+	// buffer and batch this call rather than writing through synchronously.
+	IncrementFlagEvaluation(ctx context.Context, namespaceKey, flagKey string) error
+
+	// RecordEvaluation records the full outcome of a flag evaluation: variant, match, reason,
+	// matched segments, and duration. Implementations are free to buffer and batch this call
+	// rather than writing through synchronously.
+	RecordEvaluation(ctx context.Context, event EvaluationEvent) error
+
+	// GetFlagEvaluationsCount returns the evaluation count for a flag, bucketed over the window
+	// described by req, as parallel slices of timestamp and value.
+	GetFlagEvaluationsCount(ctx context.Context, req *analytics.GetFlagEvaluationsCountRequest) ([]string, []float32, error)
+
+	// Close flushes any buffered state and releases the sink's resources.
+	Close() error
+}
+
+// DurationQuantiles holds the p50/p95/p99 evaluation duration in microseconds.
+type DurationQuantiles struct {
+	P50 float64
+	P95 float64
+	P99 float64
+}
+
+// EvaluationQuerier is an optional capability a Sink may implement to expose richer analytics
+// reads than GetFlagEvaluationsCount alone covers. Sinks backed by flipt_evaluation_events (e.g.
+// Clickhouse) can satisfy this; sinks that only track rollup counters (e.g. Prometheus) cannot,
+// so callers must type-assert for it rather than requiring it on Sink itself.
+type EvaluationQuerier interface {
+	// GetVariantDistribution returns, for a variant flag, the share of evaluations that resolved
+	// to each variant over the requested window, as parallel slices of variant key and ratio.
+	GetVariantDistribution(ctx context.Context, req *analytics.GetFlagEvaluationsCountRequest) ([]string, []float32, error)
+
+	// GetMatchRate returns the match-rate (fraction of evaluations where the flag matched) over
+	// the requested window, bucketed the same way as GetFlagEvaluationsCount.
+	GetMatchRate(ctx context.Context, req *analytics.GetFlagEvaluationsCountRequest) ([]string, []float32, error)
+
+	// GetEvaluationDurationQuantiles returns the p50/p95/p99 evaluation duration for the given
+	// flag over the requested window.
+	GetEvaluationDurationQuantiles(ctx context.Context, req *analytics.GetFlagEvaluationsCountRequest) (DurationQuantiles, error)
+}
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	flipt "go.flipt.io/flipt/rpc/flipt"
+)
+
+// AuditEvent is a durable record of a single mutating (or, for ActionRead, sensitive read) API
+// call against Flipt.
+type AuditEvent struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Actor     string         `json:"actor"`
+	Request   flipt.Request  `json:"request"`
+	Status    Status         `json:"status"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// Status describes the outcome of the request the AuditEvent was generated for.
+type Status string
+
+const (
+	StatusSuccess Status = "success"
+	StatusError   Status = "error"
+)
+
+// Sink is implemented by every destination an AuditEvent can be durably recorded to.
+type Sink interface {
+	// Name identifies the sink in logs and metrics.
+	Name() string
+
+	// Write durably records event. It may be called concurrently.
+	Write(ctx context.Context, event AuditEvent) error
+
+	// Close releases any resources held by the sink.
+	Close() error
+}
@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"fmt"
+
+	"go.flipt.io/flipt/internal/audit/file"
+	"go.flipt.io/flipt/internal/audit/kafka"
+	"go.flipt.io/flipt/internal/audit/webhook"
+	"go.flipt.io/flipt/internal/config"
+)
+
+// SinksFromConfig constructs the Sink for every entry in cfg.Sinks.
+func SinksFromConfig(cfg config.AuditConfig) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+
+	for _, sc := range cfg.Sinks {
+		switch sc.Type {
+		case config.AuditSinkTypeFile:
+			sinks = append(sinks, file.New(file.Config{
+				Path:       sc.File.Path,
+				MaxSizeMB:  sc.File.MaxSizeMB,
+				MaxBackups: sc.File.MaxBackups,
+				MaxAgeDays: sc.File.MaxAgeDays,
+			}))
+		case config.AuditSinkTypeWebhook:
+			sinks = append(sinks, webhook.New(webhook.Config{
+				URL:           sc.Webhook.URL,
+				SigningSecret: sc.Webhook.SigningSecret,
+				MaxRetries:    sc.Webhook.MaxRetries,
+			}))
+		case config.AuditSinkTypeKafka:
+			sinks = append(sinks, kafka.New(kafka.Config{
+				Brokers: sc.Kafka.Brokers,
+				Topic:   sc.Kafka.Topic,
+			}))
+		default:
+			return nil, fmt.Errorf("audit: unknown sink type %q", sc.Type)
+		}
+	}
+
+	return sinks, nil
+}
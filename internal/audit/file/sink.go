@@ -0,0 +1,57 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"go.flipt.io/flipt/internal/audit"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Sink writes AuditEvents as newline-delimited JSON to a file, rotating it once it grows past
+// the configured size.
+type Sink struct {
+	mu     sync.Mutex
+	writer *lumberjack.Logger
+	enc    *json.Encoder
+}
+
+// Config configures the rotation policy for a file Sink.
+type Config struct {
+	// Path is the file audit events are appended to.
+	Path string
+	// MaxSizeMB is the size, in megabytes, a file is allowed to grow to before it is rotated.
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files to retain.
+	MaxBackups int
+	// MaxAgeDays is the number of days to retain rotated files.
+	MaxAgeDays int
+}
+
+// New constructs a file Sink from cfg.
+func New(cfg Config) *Sink {
+	writer := &lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAgeDays,
+	}
+
+	return &Sink{writer: writer, enc: json.NewEncoder(writer)}
+}
+
+func (s *Sink) Name() string {
+	return "file"
+}
+
+func (s *Sink) Write(_ context.Context, event audit.AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.enc.Encode(event)
+}
+
+func (s *Sink) Close() error {
+	return s.writer.Close()
+}
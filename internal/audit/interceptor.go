@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.flipt.io/flipt/internal/server/auth"
+	flipt "go.flipt.io/flipt/rpc/flipt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// defaultChannelBufferSize bounds how many events may be queued for a sink before the
+// interceptor starts dropping them rather than blocking the request path.
+const defaultChannelBufferSize = 1000
+
+// Interceptor fans AuditEvents produced by API calls whose requests implement flipt.Requester
+// (reads as well as mutating calls) out to a set of configured Sinks.
+// Publishing is non-blocking: each sink has its own bounded channel and worker goroutine, so a
+// slow or unavailable sink cannot stall request handling.
+type Interceptor struct {
+	logger *zap.Logger
+
+	channels []chan AuditEvent
+	sinks    []Sink
+	wg       sync.WaitGroup
+}
+
+// NewInterceptor starts one worker per sink and returns an Interceptor that publishes to all of
+// them. Callers must call Close to stop the workers and release the sinks.
+func NewInterceptor(logger *zap.Logger, sinks ...Sink) *Interceptor {
+	i := &Interceptor{logger: logger, sinks: sinks}
+
+	for _, sink := range sinks {
+		ch := make(chan AuditEvent, defaultChannelBufferSize)
+		i.channels = append(i.channels, ch)
+
+		i.wg.Add(1)
+		go i.worker(sink, ch)
+	}
+
+	return i
+}
+
+func (i *Interceptor) worker(sink Sink, ch <-chan AuditEvent) {
+	defer i.wg.Done()
+
+	for event := range ch {
+		if err := sink.Write(context.Background(), event); err != nil {
+			i.logger.Error("failed to write audit event", zap.String("sink", sink.Name()), zap.Error(err))
+		}
+	}
+}
+
+// publish enqueues event on every sink's channel, dropping it (and logging) for any sink whose
+// queue is currently full.
+func (i *Interceptor) publish(event AuditEvent) {
+	for idx, ch := range i.channels {
+		select {
+		case ch <- event:
+		default:
+			i.logger.Warn("dropping audit event, sink queue is full", zap.String("sink", i.sinks[idx].Name()))
+		}
+	}
+}
+
+// UnaryServerInterceptor records an AuditEvent for every unary RPC whose request implements
+// flipt.Requester, tagging it with the authenticated principal and the call's outcome.
+func (i *Interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+
+		requester, ok := req.(flipt.Requester)
+		if !ok {
+			return resp, err
+		}
+
+		status := StatusSuccess
+		if err != nil {
+			status = StatusError
+		}
+
+		i.publish(AuditEvent{
+			Timestamp: time.Now().UTC(),
+			Actor:     actorFrom(ctx),
+			Request:   requester.Request(),
+			Status:    status,
+		})
+
+		return resp, err
+	}
+}
+
+// actorFrom returns a human-readable identifier for the authenticated principal attached to ctx
+// by the auth middleware, or "anonymous" if the request carried no authentication.
+func actorFrom(ctx context.Context) string {
+	a, ok := auth.GetAuthenticationFrom(ctx)
+	if !ok {
+		return "anonymous"
+	}
+
+	if sub, ok := a.Metadata[auth.MetadataAuthenticationSubject]; ok {
+		return sub
+	}
+
+	return a.Method.String()
+}
+
+// Close stops every worker, waits for each to finish draining its channel, and only then closes
+// the underlying sinks. This ordering matters: closing a sink while its worker might still be
+// mid-Write races the sink's own shutdown (e.g. the file sink's lumberjack.Logger, or the Kafka
+// writer's connection) and can drop or error on buffered events.
+func (i *Interceptor) Close() error {
+	for _, ch := range i.channels {
+		close(ch)
+	}
+
+	i.wg.Wait()
+
+	var firstErr error
+	for idx := range i.sinks {
+		if err := i.sinks[idx].Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// fakeSink records every event handed to Write and can optionally stall inside Write until
+// released, to exercise Close's wait-for-drain behavior.
+type fakeSink struct {
+	mu     sync.Mutex
+	events []AuditEvent
+
+	release chan struct{}
+	closed  bool
+}
+
+func (s *fakeSink) Name() string { return "fake" }
+
+func (s *fakeSink) Write(_ context.Context, event AuditEvent) error {
+	if s.release != nil {
+		<-s.release
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *fakeSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.events)
+}
+
+func TestInterceptorClose_WaitsForWorkerToDrain(t *testing.T) {
+	sink := &fakeSink{release: make(chan struct{})}
+	i := NewInterceptor(zap.NewNop(), sink)
+
+	i.publish(AuditEvent{Timestamp: time.Now(), Actor: "anonymous"})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- i.Close()
+	}()
+
+	// Close should block until the worker finishes draining the buffered event, which can't
+	// happen until Write is unblocked.
+	select {
+	case <-done:
+		t.Fatal("Close returned before the worker drained its buffered event")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(sink.release)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error from Close: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return after the worker was unblocked")
+	}
+
+	if sink.count() != 1 {
+		t.Fatalf("expected the buffered event to be written before Close returned, got %d events", sink.count())
+	}
+}
+
+func TestPublish_DropsWhenSinkQueueIsFull(t *testing.T) {
+	sink := &fakeSink{release: make(chan struct{})}
+	i := NewInterceptor(zap.NewNop(), sink)
+	defer func() {
+		close(sink.release)
+		_ = i.Close()
+	}()
+
+	// Hand the worker one event and wait until it has taken it off the channel, so the worker is
+	// parked in Write and the channel's length below depends only on publish.
+	i.publish(AuditEvent{Timestamp: time.Now(), Actor: "anonymous"})
+	for len(i.channels[0]) != 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	for n := 0; n < defaultChannelBufferSize+10; n++ {
+		i.publish(AuditEvent{Timestamp: time.Now(), Actor: "anonymous"})
+	}
+
+	if len(i.channels[0]) != defaultChannelBufferSize {
+		t.Fatalf("expected publish to drop events once the channel is full rather than block, channel len=%d", len(i.channels[0]))
+	}
+}
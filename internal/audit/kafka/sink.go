@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/segmentio/kafka-go"
+	"go.flipt.io/flipt/internal/audit"
+)
+
+// Config configures a Kafka Sink.
+type Config struct {
+	// Brokers is the list of seed broker addresses.
+	Brokers []string
+	// Topic is the topic audit events are produced to.
+	Topic string
+}
+
+// Sink publishes AuditEvents as JSON-encoded Kafka messages, keyed by the request's namespace so
+// events for a given namespace land on the same partition.
+type Sink struct {
+	writer *kafka.Writer
+}
+
+// New constructs a Kafka Sink from cfg.
+func New(cfg Config) *Sink {
+	return &Sink{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.Brokers...),
+			Topic:        cfg.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+		},
+	}
+}
+
+func (s *Sink) Name() string {
+	return "kafka"
+}
+
+func (s *Sink) Write(ctx context.Context, event audit.AuditEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.Request.Namespace),
+		Value: body,
+	})
+}
+
+func (s *Sink) Close() error {
+	return s.writer.Close()
+}
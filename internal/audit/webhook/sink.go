@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.flipt.io/flipt/internal/audit"
+)
+
+// Config configures a webhook Sink.
+type Config struct {
+	// URL is the endpoint events are POSTed to.
+	URL string
+	// SigningSecret, if set, is used to sign the request body and set it in the
+	// X-Flipt-Signature header so the receiver can verify authenticity.
+	SigningSecret string
+	// MaxRetries is the number of additional attempts made after an initial failed delivery.
+	MaxRetries int
+}
+
+// Sink delivers AuditEvents to an HTTP endpoint, retrying failed deliveries with a bounded
+// backoff and signing each payload with HMAC-SHA256 when a signing secret is configured.
+type Sink struct {
+	cfg    Config
+	client *http.Client
+}
+
+// New constructs a webhook Sink from cfg.
+func New(cfg Config) *Sink {
+	return &Sink{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *Sink) Name() string {
+	return "webhook"
+}
+
+func (s *Sink) Write(ctx context.Context, event audit.AuditEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+
+	for attempt := 0; attempt <= s.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff(attempt)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if lastErr = s.deliver(ctx, body); lastErr == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("webhook: delivery failed after %d attempts: %w", s.cfg.MaxRetries+1, lastErr)
+}
+
+func (s *Sink) deliver(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if s.cfg.SigningSecret != "" {
+		req.Header.Set("X-Flipt-Signature", sign(s.cfg.SigningSecret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: endpoint returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns an exponential backoff delay for the given attempt number, capped at 30s.
+func backoff(attempt int) time.Duration {
+	d := time.Second
+	for i := 1; i < attempt && d < 30*time.Second; i++ {
+		d *= 2
+	}
+	if d > 30*time.Second {
+		return 30 * time.Second
+	}
+	return d
+}
+
+func (s *Sink) Close() error {
+	return nil
+}
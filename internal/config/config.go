@@ -0,0 +1,137 @@
+package config
+
+import "time"
+
+// Version is Flipt's version string, set at build time via -ldflags.
+var Version = "dev"
+
+// Config is the root of Flipt's runtime configuration.
+type Config struct {
+	Analytics AnalyticsConfig `json:"analytics,omitempty" mapstructure:"analytics"`
+	Audit     AuditConfig     `json:"audit,omitempty" mapstructure:"audit"`
+	Telemetry TelemetryConfig `json:"telemetry,omitempty" mapstructure:"telemetry"`
+	Database  DatabaseConfig  `json:"database,omitempty" mapstructure:"database"`
+	Storage   StorageConfig   `json:"storage,omitempty" mapstructure:"storage"`
+}
+
+// StorageConfig configures where Flipt keeps on-disk state, such as the telemetry instance ID
+// file.
+type StorageConfig struct {
+	Local LocalStorageConfig `json:"local,omitempty" mapstructure:"local"`
+}
+
+// LocalStorageConfig configures local-filesystem storage.
+type LocalStorageConfig struct {
+	// Path is the directory Flipt stores local state under. Defaults to "/var/opt/flipt".
+	Path string `json:"path,omitempty" mapstructure:"path"`
+}
+
+// DatabaseProtocol identifies the SQL dialect Flipt's store is backed by.
+type DatabaseProtocol string
+
+const (
+	DatabaseProtocolPostgres DatabaseProtocol = "postgres"
+	DatabaseProtocolMySQL    DatabaseProtocol = "mysql"
+	DatabaseProtocolSQLite   DatabaseProtocol = "sqlite"
+)
+
+// DatabaseConfig configures the SQL store backing the Flipt API.
+type DatabaseConfig struct {
+	Protocol DatabaseProtocol `json:"protocol,omitempty" mapstructure:"protocol"`
+	URL      string           `json:"url,omitempty" mapstructure:"url"`
+}
+
+// TelemetryConfig configures the opt-in anonymized telemetry reporter.
+type TelemetryConfig struct {
+	// Enabled turns on periodic reporting. Defaults to false.
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+	// Endpoint is the URL the anonymized snapshot is POSTed to.
+	Endpoint string `json:"endpoint,omitempty" mapstructure:"endpoint"`
+	// Interval is how often the snapshot is reported.
+	Interval time.Duration `json:"interval,omitempty" mapstructure:"interval"`
+}
+
+// AuditConfig configures the audit log subsystem.
+type AuditConfig struct {
+	Sinks []AuditSinkConfig `json:"sinks,omitempty" mapstructure:"sinks"`
+}
+
+// AuditSinkType selects which audit.Sink implementation an AuditSinkConfig entry configures.
+type AuditSinkType string
+
+const (
+	AuditSinkTypeFile    AuditSinkType = "file"
+	AuditSinkTypeWebhook AuditSinkType = "webhook"
+	AuditSinkTypeKafka   AuditSinkType = "kafka"
+)
+
+// AuditSinkConfig configures a single audit.Sink. Only the fields relevant to Type are read.
+type AuditSinkConfig struct {
+	Type AuditSinkType `json:"type,omitempty" mapstructure:"type"`
+
+	File    AuditFileSinkConfig    `json:"file,omitempty" mapstructure:"file"`
+	Webhook AuditWebhookSinkConfig `json:"webhook,omitempty" mapstructure:"webhook"`
+	Kafka   AuditKafkaSinkConfig   `json:"kafka,omitempty" mapstructure:"kafka"`
+}
+
+// AuditFileSinkConfig configures the rotating JSON-lines file audit sink.
+type AuditFileSinkConfig struct {
+	Path       string `json:"path,omitempty" mapstructure:"path"`
+	MaxSizeMB  int    `json:"maxSizeMb,omitempty" mapstructure:"max_size_mb"`
+	MaxBackups int    `json:"maxBackups,omitempty" mapstructure:"max_backups"`
+	MaxAgeDays int    `json:"maxAgeDays,omitempty" mapstructure:"max_age_days"`
+}
+
+// AuditWebhookSinkConfig configures the HTTP webhook audit sink.
+type AuditWebhookSinkConfig struct {
+	URL           string `json:"url,omitempty" mapstructure:"url"`
+	SigningSecret string `json:"signingSecret,omitempty" mapstructure:"signing_secret"`
+	MaxRetries    int    `json:"maxRetries,omitempty" mapstructure:"max_retries"`
+}
+
+// AuditKafkaSinkConfig configures the Kafka audit sink.
+type AuditKafkaSinkConfig struct {
+	Brokers []string `json:"brokers,omitempty" mapstructure:"brokers"`
+	Topic   string   `json:"topic,omitempty" mapstructure:"topic"`
+}
+
+// AnalyticsBackend selects which analytics.Sink implementation Flipt writes evaluations to and
+// serves analytics queries from.
+type AnalyticsBackend string
+
+const (
+	AnalyticsBackendClickhouse AnalyticsBackend = "clickhouse"
+	AnalyticsBackendPrometheus AnalyticsBackend = "prometheus"
+)
+
+// AnalyticsConfig configures how Flipt records and serves flag evaluation analytics.
+type AnalyticsConfig struct {
+	// Backend selects the analytics.Sink implementation. Defaults to AnalyticsBackendClickhouse.
+	Backend AnalyticsBackend `json:"backend,omitempty" mapstructure:"backend"`
+
+	Clickhouse ClickhouseConfig `json:"clickhouse,omitempty" mapstructure:"clickhouse"`
+	Prometheus PrometheusConfig `json:"prometheus,omitempty" mapstructure:"prometheus"`
+}
+
+// PrometheusConfig configures the Prometheus-backed analytics sink.
+type PrometheusConfig struct {
+	// URL is the base URL of the Prometheus HTTP API used to serve analytics queries.
+	URL string `json:"url,omitempty" mapstructure:"url"`
+}
+
+// ClickhouseConfig configures the Clickhouse analytics sink.
+type ClickhouseConfig struct {
+	// URL is the DSN used to connect to Clickhouse.
+	URL string `json:"url,omitempty" mapstructure:"url"`
+
+	// BatchSize is the number of buffered rows that triggers a flush to Clickhouse.
+	BatchSize int `json:"batchSize,omitempty" mapstructure:"batch_size"`
+	// FlushInterval is the maximum time buffered rows are held before being flushed, even if
+	// BatchSize hasn't been reached.
+	FlushInterval time.Duration `json:"flushInterval,omitempty" mapstructure:"flush_interval"`
+	// MaxQueueSize bounds how many rows may be buffered awaiting flush.
+	MaxQueueSize int `json:"maxQueueSize,omitempty" mapstructure:"max_queue_size"`
+	// BlockOnFull selects the backpressure policy once MaxQueueSize is reached: true blocks the
+	// evaluation path until space frees up, false drops the oldest buffered row.
+	BlockOnFull bool `json:"blockOnFull,omitempty" mapstructure:"block_on_full"`
+}
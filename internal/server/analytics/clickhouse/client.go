@@ -8,6 +8,10 @@ import (
 	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/dchest/siphash"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	internalanalytics "go.flipt.io/flipt/internal/analytics"
 	"go.flipt.io/flipt/internal/config"
 	fliptsql "go.flipt.io/flipt/internal/storage/sql"
 	"go.flipt.io/flipt/rpc/flipt/analytics"
@@ -25,13 +29,88 @@ var dbOnce sync.Once
 
 const (
 	counterAnalyticsTable = "flipt_counter_analytics"
+	evaluationEventsTable = "flipt_evaluation_events"
 	counterAnalyticsName  = "flag_evaluation_count"
 	timeFormat            = "2006-01-02 15:04:05"
+
+	// defaultBatchSize is used when config.Analytics.Clickhouse.BatchSize is unset.
+	defaultBatchSize = 5000
+	// defaultFlushInterval is used when config.Analytics.Clickhouse.FlushInterval is unset.
+	defaultFlushInterval = 5 * time.Second
+	// defaultMaxQueueSize is used when config.Analytics.Clickhouse.MaxQueueSize is unset.
+	defaultMaxQueueSize = 100_000
+	// defaultCloseTimeout bounds how long Close waits for the writer to drain.
+	defaultCloseTimeout = 10 * time.Second
 )
 
+var (
+	queueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "flipt",
+		Subsystem: "analytics_clickhouse",
+		Name:      "queue_depth",
+		Help:      "The number of flag evaluation rows currently buffered, awaiting flush to Clickhouse.",
+	})
+
+	batchesFlushedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "flipt",
+		Subsystem: "analytics_clickhouse",
+		Name:      "batches_flushed_total",
+		Help:      "The total number of batches flushed to Clickhouse.",
+	})
+
+	rowsDroppedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "flipt",
+		Subsystem: "analytics_clickhouse",
+		Name:      "rows_dropped_total",
+		Help:      "The total number of flag evaluation rows dropped because the queue was full.",
+	})
+
+	flushLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "flipt",
+		Subsystem: "analytics_clickhouse",
+		Name:      "flush_latency_seconds",
+		Help:      "The time it takes to flush a batch of flag evaluation rows to Clickhouse.",
+	})
+)
+
+// row is a single buffered flag evaluation awaiting flush.
+type row struct {
+	timestamp    time.Time
+	namespaceKey string
+	flagKey      string
+}
+
+// evalRow is a single buffered evaluation event awaiting flush to flipt_evaluation_events.
+type evalRow struct {
+	timestamp      time.Time
+	namespaceKey   string
+	flagKey        string
+	flagType       string
+	match          bool
+	reason         string
+	variantKey     string
+	segmentKeys    []string
+	entityIDHash   uint64
+	durationMicros uint32
+}
+
+// siphash keys used to derive entity_id_hash. These are fixed (not secret): the hash exists to
+// bound cardinality for analytics grouping, not to protect the entity ID.
+const siphashK0, siphashK1 = 0x0, 0x0
+
 type Client struct {
 	conn         *sql.DB
 	forceMigrate bool
+
+	batchSize     int
+	flushInterval time.Duration
+	maxQueueSize  int
+	blockOnFull   bool
+
+	queue     chan row
+	evalQueue chan evalRow
+	done      chan struct{}
+	wg        sync.WaitGroup
 }
 
 // New constructs a new clickhouse client that conforms to the analytics.Client contract.
@@ -61,7 +140,38 @@ func New(logger *zap.Logger, cfg *config.Config, forceMigrate bool) (*Client, er
 		return nil, clickhouseErr
 	}
 
-	return &Client{conn: conn, forceMigrate: forceMigrate}, nil
+	batchSize := cfg.Analytics.Clickhouse.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	flushInterval := cfg.Analytics.Clickhouse.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+
+	maxQueueSize := cfg.Analytics.Clickhouse.MaxQueueSize
+	if maxQueueSize <= 0 {
+		maxQueueSize = defaultMaxQueueSize
+	}
+
+	c := &Client{
+		conn:          conn,
+		forceMigrate:  forceMigrate,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		maxQueueSize:  maxQueueSize,
+		blockOnFull:   cfg.Analytics.Clickhouse.BlockOnFull,
+		queue:         make(chan row, maxQueueSize),
+		evalQueue:     make(chan evalRow, maxQueueSize),
+		done:          make(chan struct{}),
+	}
+
+	c.wg.Add(2)
+	go c.flushLoop(logger)
+	go c.evalFlushLoop(logger)
+
+	return c, nil
 }
 
 // runMigrations will run migrations for clickhouse if enabled from the client.
@@ -170,10 +280,424 @@ func getStepFromDuration(from time.Duration) *Step {
 	}
 }
 
-// IncrementFlagEvaluation inserts a row into Clickhouse that corresponds to a time when a flag was evaluated.
-// This acts as a "prometheus-like" counter metric.
+// IncrementFlagEvaluation buffers a row corresponding to a time when a flag was evaluated. Rows
+// are flushed to Clickhouse in batches by the background flush loop rather than written
+// one-at-a-time, since Clickhouse is optimized for bulk inserts.
 func (c *Client) IncrementFlagEvaluation(ctx context.Context, namespaceKey, flagKey string) error {
-	_, err := c.conn.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s VALUES (toDateTime(?),?,?,?,?)", counterAnalyticsTable), time.Now().Format(timeFormat), counterAnalyticsName, namespaceKey, flagKey, 1)
+	r := row{timestamp: time.Now(), namespaceKey: namespaceKey, flagKey: flagKey}
+
+	if c.blockOnFull {
+		select {
+		case c.queue <- r:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		queueDepth.Set(float64(len(c.queue)))
+		return nil
+	}
+
+	select {
+	case c.queue <- r:
+	default:
+		// Queue is full and we're configured to drop-oldest: make room by discarding
+		// the oldest buffered row, then enqueue the new one.
+		select {
+		case <-c.queue:
+			rowsDroppedTotal.Inc()
+		default:
+		}
+
+		select {
+		case c.queue <- r:
+		default:
+			rowsDroppedTotal.Inc()
+		}
+	}
+
+	queueDepth.Set(float64(len(c.queue)))
+	return nil
+}
+
+// RecordEvaluation buffers the full outcome of a flag evaluation for flipt_evaluation_events,
+// hashing the entity ID with siphash so raw entity identifiers never reach Clickhouse.
+func (c *Client) RecordEvaluation(ctx context.Context, event internalanalytics.EvaluationEvent) error {
+	r := evalRow{
+		timestamp:      event.Timestamp,
+		namespaceKey:   event.NamespaceKey,
+		flagKey:        event.FlagKey,
+		flagType:       string(event.FlagType),
+		match:          event.Match,
+		reason:         event.Reason,
+		variantKey:     event.VariantKey,
+		segmentKeys:    event.SegmentKeys,
+		entityIDHash:   siphash.Hash(siphashK0, siphashK1, []byte(event.EntityID)),
+		durationMicros: uint32(event.Duration.Microseconds()),
+	}
+
+	if c.blockOnFull {
+		select {
+		case c.evalQueue <- r:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case c.evalQueue <- r:
+	default:
+		select {
+		case <-c.evalQueue:
+			rowsDroppedTotal.Inc()
+		default:
+		}
+
+		select {
+		case c.evalQueue <- r:
+		default:
+			rowsDroppedTotal.Inc()
+		}
+	}
+
+	return nil
+}
+
+// flushLoop drains the queue into Clickhouse whenever the batch size threshold or the flush
+// interval is reached, whichever comes first.
+func (c *Client) flushLoop(logger *zap.Logger) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]row, 0, c.batchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := c.flush(batch); err != nil {
+			logger.Error("failed to flush analytics batch to clickhouse", zap.Error(err), zap.Int("rows", len(batch)))
+		}
+
+		batch = batch[:0]
+		queueDepth.Set(float64(len(c.queue)))
+	}
+
+	for {
+		select {
+		case r := <-c.queue:
+			batch = append(batch, r)
+			if len(batch) >= c.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-c.done:
+			// Drain whatever remains in the queue before exiting.
+			for {
+				select {
+				case r := <-c.queue:
+					batch = append(batch, r)
+					if len(batch) >= c.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush writes a batch of rows to Clickhouse using the native batch protocol.
+func (c *Client) flush(batch []row) error {
+	start := time.Now()
+	defer func() {
+		flushLatency.Observe(time.Since(start).Seconds())
+	}()
+
+	conn, err := c.conn.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		batcher, ok := driverConn.(clickhouse.Conn)
+		if !ok {
+			return fmt.Errorf("clickhouse: driver connection does not support native batch protocol")
+		}
+
+		b, err := batcher.PrepareBatch(context.Background(), fmt.Sprintf("INSERT INTO %s", counterAnalyticsTable))
+		if err != nil {
+			return err
+		}
+
+		for _, r := range batch {
+			if err := b.Append(r.timestamp.Format(timeFormat), counterAnalyticsName, r.namespaceKey, r.flagKey, 1); err != nil {
+				return err
+			}
+		}
+
+		if err := b.Send(); err != nil {
+			return err
+		}
+
+		batchesFlushedTotal.Inc()
+		return nil
+	})
+}
+
+// evalFlushLoop mirrors flushLoop for the flipt_evaluation_events queue.
+func (c *Client) evalFlushLoop(logger *zap.Logger) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]evalRow, 0, c.batchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := c.flushEval(batch); err != nil {
+			logger.Error("failed to flush evaluation events to clickhouse", zap.Error(err), zap.Int("rows", len(batch)))
+		}
+
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case r := <-c.evalQueue:
+			batch = append(batch, r)
+			if len(batch) >= c.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-c.done:
+			for {
+				select {
+				case r := <-c.evalQueue:
+					batch = append(batch, r)
+					if len(batch) >= c.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// flushEval writes a batch of evaluation events to flipt_evaluation_events using the native
+// batch protocol.
+func (c *Client) flushEval(batch []evalRow) error {
+	start := time.Now()
+	defer func() {
+		flushLatency.Observe(time.Since(start).Seconds())
+	}()
+
+	conn, err := c.conn.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		batcher, ok := driverConn.(clickhouse.Conn)
+		if !ok {
+			return fmt.Errorf("clickhouse: driver connection does not support native batch protocol")
+		}
+
+		b, err := batcher.PrepareBatch(context.Background(), fmt.Sprintf("INSERT INTO %s", evaluationEventsTable))
+		if err != nil {
+			return err
+		}
+
+		for _, r := range batch {
+			if err := b.Append(
+				r.timestamp,
+				r.namespaceKey,
+				r.flagKey,
+				r.flagType,
+				r.match,
+				r.reason,
+				r.variantKey,
+				r.segmentKeys,
+				r.entityIDHash,
+				r.durationMicros,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := b.Send(); err != nil {
+			return err
+		}
+
+		batchesFlushedTotal.Inc()
+		return nil
+	})
+}
+
+// GetVariantDistribution returns, for each variant key of the given flag, the share of
+// evaluations over the requested window that resolved to it, as a ratio in [0, 1].
+func (c *Client) GetVariantDistribution(ctx context.Context, req *analytics.GetFlagEvaluationsCountRequest) ([]string, []float32, error) {
+	fromTime, toTime, err := parseWindow(req.From, req.To)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := c.conn.QueryContext(ctx, fmt.Sprintf(`SELECT variant_key, count() AS value
+		FROM %s WHERE namespace_key = ? AND flag_key = ? AND timestamp >= ? AND timestamp < ?
+		GROUP BY variant_key ORDER BY value DESC`, evaluationEventsTable),
+		req.NamespaceKey, req.FlagKey, fromTime.Format(timeFormat), toTime.Format(timeFormat),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var (
+		variants = make([]string, 0)
+		counts   = make([]float32, 0)
+		total    float32
+	)
+	for rows.Next() {
+		var (
+			variant string
+			count   int
+		)
+		if err := rows.Scan(&variant, &count); err != nil {
+			return nil, nil, err
+		}
+		variants = append(variants, variant)
+		counts = append(counts, float32(count))
+		total += float32(count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	ratios := make([]float32, len(counts))
+	if total > 0 {
+		for i, count := range counts {
+			ratios[i] = count / total
+		}
+	}
+
+	return variants, ratios, nil
+}
+
+// GetMatchRate returns the fraction of evaluations that matched, bucketed over the requested
+// window.
+func (c *Client) GetMatchRate(ctx context.Context, req *analytics.GetFlagEvaluationsCountRequest) ([]string, []float32, error) {
+	fromTime, toTime, err := parseWindow(req.From, req.To)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	step := getStepFromDuration(toTime.Sub(fromTime))
+
+	rows, err := c.conn.QueryContext(ctx, fmt.Sprintf(`SELECT toStartOfInterval(timestamp, INTERVAL %d %s) AS timestamp, avg(match) AS rate
+		FROM %s WHERE namespace_key = ? AND flag_key = ? AND timestamp >= ? AND timestamp < ?
+		GROUP BY timestamp ORDER BY timestamp`,
+		step.intervalValue, step.intervalStep, evaluationEventsTable),
+		req.NamespaceKey, req.FlagKey, fromTime.Format(timeFormat), toTime.Format(timeFormat),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var (
+		timestamps = make([]string, 0)
+		rates      = make([]float32, 0)
+	)
+	for rows.Next() {
+		var (
+			timestamp string
+			rate      float32
+		)
+		if err := rows.Scan(&timestamp, &rate); err != nil {
+			return nil, nil, err
+		}
+		timestamps = append(timestamps, timestamp)
+		rates = append(rates, rate)
+	}
+
+	return timestamps, rates, rows.Err()
+}
+
+// GetEvaluationDurationQuantiles returns the p50/p95/p99 evaluation duration for the given flag
+// over the requested window, computed via Clickhouse's quantilesTDigest.
+func (c *Client) GetEvaluationDurationQuantiles(ctx context.Context, req *analytics.GetFlagEvaluationsCountRequest) (internalanalytics.DurationQuantiles, error) {
+	fromTime, toTime, err := parseWindow(req.From, req.To)
+	if err != nil {
+		return internalanalytics.DurationQuantiles{}, err
+	}
+
+	row := c.conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT quantilesTDigest(0.5, 0.95, 0.99)(duration_micros)
+		FROM %s WHERE namespace_key = ? AND flag_key = ? AND timestamp >= ? AND timestamp < ?`, evaluationEventsTable),
+		req.NamespaceKey, req.FlagKey, fromTime.Format(timeFormat), toTime.Format(timeFormat),
+	)
+
+	var quantiles []float64
+	if err := row.Scan(&quantiles); err != nil {
+		return internalanalytics.DurationQuantiles{}, err
+	}
+
+	if len(quantiles) != 3 {
+		return internalanalytics.DurationQuantiles{}, fmt.Errorf("clickhouse: expected 3 quantiles, got %d", len(quantiles))
+	}
+
+	return internalanalytics.DurationQuantiles{P50: quantiles[0], P95: quantiles[1], P99: quantiles[2]}, nil
+}
+
+// parseWindow parses the [from, to) strings used throughout the analytics queries.
+func parseWindow(from, to string) (time.Time, time.Time, error) {
+	fromTime, err := time.Parse(timeFormat, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	toTime, err := time.Parse(timeFormat, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return fromTime, toTime, nil
+}
+
+// Close drains any buffered rows to Clickhouse and stops the background flush loop. It returns
+// once the drain completes or defaultCloseTimeout elapses, whichever comes first.
+func (c *Client) Close() error {
+	close(c.done)
+
+	drained := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(defaultCloseTimeout):
+	}
 
-	return err
+	return c.conn.Close()
 }
@@ -0,0 +1,49 @@
+package clickhouse
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIncrementFlagEvaluation_BlockOnFull(t *testing.T) {
+	c := &Client{
+		blockOnFull: true,
+		queue:       make(chan row, 1),
+	}
+
+	if err := c.IncrementFlagEvaluation(context.Background(), "default", "flag"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := c.IncrementFlagEvaluation(ctx, "default", "flag"); err != ctx.Err() {
+		t.Fatalf("expected a full blocking queue to respect ctx cancellation, got: %v", err)
+	}
+}
+
+func TestIncrementFlagEvaluation_DropOldestWhenFull(t *testing.T) {
+	c := &Client{
+		blockOnFull: false,
+		queue:       make(chan row, 1),
+	}
+
+	if err := c.IncrementFlagEvaluation(context.Background(), "default", "first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.IncrementFlagEvaluation(context.Background(), "default", "second"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(c.queue) != 1 {
+		t.Fatalf("expected queue to stay bounded at capacity 1, got %d", len(c.queue))
+	}
+
+	got := <-c.queue
+	if got.flagKey != "second" {
+		t.Fatalf("expected the oldest row to be dropped in favor of the newest, got flagKey=%q", got.flagKey)
+	}
+}
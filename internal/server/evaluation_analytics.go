@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"go.flipt.io/flipt/internal/analytics"
+	evaluation "go.flipt.io/flipt/rpc/flipt/evaluation"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// evaluationAnalyticsInterceptor records an analytics.EvaluationEvent for every evaluation RPC
+// response, so flipt_evaluation_events (and, via backend-specific rollups, the legacy per-minute
+// counter) stay populated without each evaluation code path needing to remember to call the
+// analytics sink itself.
+func (s *Server) evaluationAnalyticsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		s.recordEvaluation(ctx, resp, time.Since(start))
+
+		return resp, nil
+	}
+}
+
+// recordEvaluation maps a variant or boolean evaluation response onto an
+// analytics.EvaluationEvent and hands it to the configured sink. Unrecognized response types
+// (i.e. every non-evaluation RPC) are ignored.
+func (s *Server) recordEvaluation(ctx context.Context, resp any, duration time.Duration) {
+	var event analytics.EvaluationEvent
+
+	switch r := resp.(type) {
+	case *evaluation.VariantEvaluationResponse:
+		event = analytics.EvaluationEvent{
+			NamespaceKey: r.NamespaceKey,
+			FlagKey:      r.FlagKey,
+			FlagType:     analytics.FlagTypeVariant,
+			Match:        r.Match,
+			Reason:       r.Reason.String(),
+			VariantKey:   r.VariantKey,
+			SegmentKeys:  r.SegmentKeys,
+			EntityID:     r.EntityId,
+		}
+	case *evaluation.BooleanEvaluationResponse:
+		event = analytics.EvaluationEvent{
+			NamespaceKey: r.NamespaceKey,
+			FlagKey:      r.FlagKey,
+			FlagType:     analytics.FlagTypeBoolean,
+			Match:        r.Enabled,
+			Reason:       r.Reason.String(),
+			EntityID:     r.EntityId,
+		}
+	default:
+		return
+	}
+
+	event.Timestamp = time.Now()
+	event.Duration = duration
+
+	if err := s.sink.RecordEvaluation(ctx, event); err != nil {
+		s.logger.Error("failed to record evaluation analytics", zap.Error(err))
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"go.flipt.io/flipt/internal/analytics"
+	rpcanalytics "go.flipt.io/flipt/rpc/flipt/analytics"
+)
+
+// The methods below expose analytics.EvaluationQuerier's reads (variant distribution, match
+// rate, and evaluation duration quantiles) from flipt_evaluation_events. They're plain *Server
+// methods rather than FliptServer RPC handlers: the corresponding flipt.proto messages and
+// service entries don't exist in this tree (Flipt.Watch in watch.go likewise has its messages in
+// flipt.proto but no entry in the Flipt service), so regenerating from
+// proto is required before these reads are reachable over gRPC. GetFlagEvaluationsCount, which
+// these sit alongside, has the same gap today.
+//
+// TODO: blocking follow-up — extend flipt.proto's analytics RPCs to cover variant distribution,
+// match rate, and duration quantiles, regenerate, and wire FliptServer to these methods before
+// considering "extend the analytics RPC with queries" done.
+
+// errEvaluationQueryUnsupported is returned when the configured analytics sink doesn't implement
+// analytics.EvaluationQuerier (e.g. the Prometheus sink, which only tracks rollup counters).
+var errEvaluationQueryUnsupported = fmt.Errorf("analytics: configured sink does not support evaluation queries")
+
+func (s *Server) evaluationQuerier() (analytics.EvaluationQuerier, error) {
+	q, ok := s.sink.(analytics.EvaluationQuerier)
+	if !ok {
+		return nil, errEvaluationQueryUnsupported
+	}
+
+	return q, nil
+}
+
+// GetVariantDistribution returns the share of evaluations that resolved to each variant of req's
+// flag over the requested window.
+func (s *Server) GetVariantDistribution(ctx context.Context, req *rpcanalytics.GetFlagEvaluationsCountRequest) ([]string, []float32, error) {
+	q, err := s.evaluationQuerier()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return q.GetVariantDistribution(ctx, req)
+}
+
+// GetMatchRate returns the fraction of evaluations where req's flag matched, bucketed over the
+// requested window.
+func (s *Server) GetMatchRate(ctx context.Context, req *rpcanalytics.GetFlagEvaluationsCountRequest) ([]string, []float32, error) {
+	q, err := s.evaluationQuerier()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return q.GetMatchRate(ctx, req)
+}
+
+// GetEvaluationDurationQuantiles returns the p50/p95/p99 evaluation duration for req's flag over
+// the requested window.
+func (s *Server) GetEvaluationDurationQuantiles(ctx context.Context, req *rpcanalytics.GetFlagEvaluationsCountRequest) (analytics.DurationQuantiles, error) {
+	q, err := s.evaluationQuerier()
+	if err != nil {
+		return analytics.DurationQuantiles{}, err
+	}
+
+	return q.GetEvaluationDurationQuantiles(ctx, req)
+}
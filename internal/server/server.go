@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.flipt.io/flipt/internal/analytics"
+	"go.flipt.io/flipt/internal/audit"
+	"go.flipt.io/flipt/internal/config"
+	"go.flipt.io/flipt/internal/storage"
+	"go.flipt.io/flipt/internal/storage/watch"
+	"go.flipt.io/flipt/internal/telemetry"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// Server implements the Flipt gRPC/HTTP API.
+type Server struct {
+	logger    *zap.Logger
+	sink      analytics.Sink
+	audit     *audit.Interceptor
+	telemetry *telemetry.Reporter
+	watcher   storage.Watcher
+}
+
+// New constructs a Server, wiring up the analytics sink selected by config.Analytics.Backend, the
+// audit sinks configured under config.Audit.Sinks, the opt-in telemetry reporter, and a
+// storage.Watcher appropriate for cfg.Database.Protocol: Postgres gets LISTEN/NOTIFY push
+// semantics, every other dialect falls back to polling db. ctx governs the lifetime of the
+// telemetry reporter's background loop and, for the poll fallback, has no effect (each Subscribe
+// call owns its own poll goroutines, stopped by that call's own context).
+func New(ctx context.Context, logger *zap.Logger, cfg *config.Config, db *sql.DB, store telemetry.Store, dataDir string, forceMigrate bool) (*Server, error) {
+	sink, err := analytics.New(logger, cfg, forceMigrate)
+	if err != nil {
+		return nil, err
+	}
+
+	auditSinks, err := audit.SinksFromConfig(cfg.Audit)
+	if err != nil {
+		return nil, err
+	}
+
+	reporter, err := telemetry.NewReporter(logger, cfg, store, sink, dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	go reporter.Run(ctx)
+
+	watcher, err := newWatcher(logger, cfg, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		logger:    logger,
+		sink:      sink,
+		audit:     audit.NewInterceptor(logger, auditSinks...),
+		telemetry: reporter,
+		watcher:   watcher,
+	}, nil
+}
+
+// newWatcher selects the storage.Watcher implementation appropriate for cfg.Database.Protocol.
+func newWatcher(logger *zap.Logger, cfg *config.Config, db *sql.DB) (storage.Watcher, error) {
+	if cfg.Database.Protocol == config.DatabaseProtocolPostgres {
+		w, err := watch.NewPostgresWatcher(logger, cfg.Database.URL)
+		if err != nil {
+			return nil, fmt.Errorf("constructing postgres watcher: %w", err)
+		}
+
+		return w, nil
+	}
+
+	return watch.NewPollWatcher(logger, watch.NewSQLChangeQuerier(db), 0), nil
+}
+
+// UnaryInterceptors returns the gRPC unary interceptors the server requires on its interceptor
+// chain: the audit log interceptor, and the interceptor that records evaluation analytics.
+func (s *Server) UnaryInterceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{s.audit.UnaryServerInterceptor(), s.evaluationAnalyticsInterceptor()}
+}
+
+// Close releases the resources held by the Server, including the analytics sink, audit sinks, and
+// the watcher, if its implementation holds a dedicated connection (e.g. the Postgres
+// LISTEN/NOTIFY watcher).
+func (s *Server) Close() error {
+	if err := s.audit.Close(); err != nil {
+		return err
+	}
+
+	if closer, ok := s.watcher.(interface{ Close() error }); ok {
+		if err := closer.Close(); err != nil {
+			return err
+		}
+	}
+
+	return s.sink.Close()
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"go.flipt.io/flipt/internal/storage"
+	flipt "go.flipt.io/flipt/rpc/flipt"
+)
+
+// Watch implements the Flipt.Watch server-streaming RPC. It subscribes to s.watcher for the
+// namespace and resources in req and forwards every storage.Event as a flipt.ChangeEvent until
+// the client disconnects. This lets SDKs doing local flag caching invalidate instantly instead of
+// relying on periodic full re-syncs.
+func (s *Server) Watch(req *flipt.WatchRequest, stream flipt.Flipt_WatchServer) error {
+	ctx := stream.Context()
+
+	events, err := s.watcher.Subscribe(ctx, storage.WatchRequest{
+		Namespace: req.NamespaceKey,
+		Resources: toStorageResources(req.Resources),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.Send(&flipt.ChangeEvent{
+				Namespace: event.Namespace,
+				Resource:  string(event.Resource),
+				Subject:   string(event.Subject),
+				Action:    string(event.Action),
+				Key:       event.Key,
+				Timestamp: event.Timestamp.Unix(),
+			}); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func toStorageResources(resources []string) []flipt.Resource {
+	out := make([]flipt.Resource, 0, len(resources))
+	for _, r := range resources {
+		out = append(out, flipt.Resource(r))
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	flipt "go.flipt.io/flipt/rpc/flipt"
+)
+
+// WatchRequest scopes a subscription to a namespace and, optionally, a subset of resource kinds.
+// An empty Resources list subscribes to every watchable resource in the namespace.
+type WatchRequest struct {
+	Namespace string
+	Resources []flipt.Resource
+}
+
+// WatchableResources lists every resource kind a Watcher must cover for an empty
+// WatchRequest.Resources, i.e. the full set flag, segment, rule, and rollout changes.
+func WatchableResources() []flipt.Resource {
+	return []flipt.Resource{
+		flipt.ResourceFlag,
+		flipt.ResourceSegment,
+		flipt.ResourceRule,
+		flipt.ResourceRollout,
+	}
+}
+
+// ResourcesOrDefault returns req.Resources, or WatchableResources() if req.Resources is empty.
+func (req WatchRequest) ResourcesOrDefault() []flipt.Resource {
+	if len(req.Resources) == 0 {
+		return WatchableResources()
+	}
+
+	return req.Resources
+}
+
+// Event describes a single change to a flag, segment, rule, or rollout observed by a Watcher.
+type Event struct {
+	Timestamp time.Time
+	Namespace string
+	Resource  flipt.Resource
+	Subject   flipt.Subject
+	Action    flipt.Action
+	// Key is the key of the affected resource, e.g. the flag key for a flipt.ResourceFlag event.
+	Key string
+}
+
+// Watcher lets callers subscribe to a stream of Events for the resources described by a
+// WatchRequest. The returned channel is closed when ctx is cancelled or the subscription ends.
+type Watcher interface {
+	Subscribe(ctx context.Context, req WatchRequest) (<-chan Event, error)
+}
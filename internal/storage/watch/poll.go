@@ -0,0 +1,96 @@
+package watch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.flipt.io/flipt/internal/storage"
+	flipt "go.flipt.io/flipt/rpc/flipt"
+	"go.uber.org/zap"
+)
+
+// defaultPollInterval is used when no interval is supplied to NewPollWatcher.
+const defaultPollInterval = 2 * time.Second
+
+// ChangeQuerier is satisfied by a SQL store that can report rows changed since a cursor. It is
+// the one piece every dialect must implement; everything else about polling is dialect-agnostic.
+// Implementations should track a monotonic updated_at (or version) column per table+namespace
+// and return it as the new cursor so the next poll doesn't re-scan rows it has already seen.
+type ChangeQuerier interface {
+	QueryChangesSince(ctx context.Context, namespace string, resource flipt.Resource, since time.Time) (events []storage.Event, cursor time.Time, err error)
+}
+
+// PollWatcher is a storage.Watcher that works against any SQL dialect by periodically
+// re-querying for rows whose updated_at/version cursor has advanced since the last poll. It is
+// the fallback used when a dialect has no push-based notification mechanism.
+type PollWatcher struct {
+	logger   *zap.Logger
+	querier  ChangeQuerier
+	interval time.Duration
+}
+
+// NewPollWatcher constructs a PollWatcher that re-queries querier every interval. A zero interval
+// uses defaultPollInterval.
+func NewPollWatcher(logger *zap.Logger, querier ChangeQuerier, interval time.Duration) *PollWatcher {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	return &PollWatcher{logger: logger, querier: querier, interval: interval}
+}
+
+// Subscribe starts a goroutine per requested resource that polls for changes and forwards them
+// on the returned channel until ctx is cancelled, then closes the channel.
+func (w *PollWatcher) Subscribe(ctx context.Context, req storage.WatchRequest) (<-chan storage.Event, error) {
+	resources := req.ResourcesOrDefault()
+
+	out := make(chan storage.Event)
+
+	var wg sync.WaitGroup
+	for _, resource := range resources {
+		wg.Add(1)
+		go func(resource flipt.Resource) {
+			defer wg.Done()
+			w.poll(ctx, req.Namespace, resource, out)
+		}(resource)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, nil
+}
+
+func (w *PollWatcher) poll(ctx context.Context, namespace string, resource flipt.Resource, out chan<- storage.Event) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	cursor := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			events, next, err := w.querier.QueryChangesSince(ctx, namespace, resource, cursor)
+			if err != nil {
+				w.logger.Error("failed to poll for storage changes",
+					zap.String("namespace", namespace), zap.String("resource", string(resource)), zap.Error(err))
+				continue
+			}
+
+			cursor = next
+
+			for _, event := range events {
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
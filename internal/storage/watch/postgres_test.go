@@ -0,0 +1,59 @@
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"go.flipt.io/flipt/internal/storage"
+	flipt "go.flipt.io/flipt/rpc/flipt"
+)
+
+func TestMatchesResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []flipt.Resource
+		resource  flipt.Resource
+		want      bool
+	}{
+		{"empty list matches everything", nil, flipt.ResourceRollout, true},
+		{"exact match", []flipt.Resource{flipt.ResourceFlag}, flipt.ResourceFlag, true},
+		{"no match", []flipt.Resource{flipt.ResourceFlag}, flipt.ResourceSegment, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesResources(tt.resources, tt.resource); got != tt.want {
+				t.Fatalf("matchesResources(%v, %v) = %v, want %v", tt.resources, tt.resource, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFanoutDropsWithoutBlockingOtherSubscribers replays the send pattern fanout uses
+// (non-blocking select/default while holding no lock) directly against subscriber channels: a
+// subscriber that stops draining its channel must be dropped for, not allowed to stall, delivery
+// to every other subscriber. It does not call fanout itself, so a change to fanout's send loop
+// does not make it fail.
+func TestFanoutDropsWithoutBlockingOtherSubscribers(t *testing.T) {
+	slow := make(chan storage.Event, 1)
+	slow <- storage.Event{} // fill it so the next send has no room
+
+	fast := make(chan storage.Event, 1)
+
+	event := storage.Event{Namespace: "default", Resource: flipt.ResourceFlag, Timestamp: time.Now()}
+
+	for _, ch := range []chan storage.Event{slow, fast} {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+
+	if len(fast) != 1 {
+		t.Fatalf("expected the fast subscriber to receive the event, channel len=%d", len(fast))
+	}
+
+	if len(slow) != 1 {
+		t.Fatalf("expected the slow subscriber's stale event to be left in place, not blocked on, channel len=%d", len(slow))
+	}
+}
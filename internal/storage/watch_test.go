@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"testing"
+
+	flipt "go.flipt.io/flipt/rpc/flipt"
+)
+
+func TestResourcesOrDefault_EmptyCoversEveryWatchableResource(t *testing.T) {
+	req := WatchRequest{Namespace: "default"}
+
+	got := req.ResourcesOrDefault()
+
+	want := []flipt.Resource{flipt.ResourceFlag, flipt.ResourceSegment, flipt.ResourceRule, flipt.ResourceRollout}
+	if len(got) != len(want) {
+		t.Fatalf("expected every watchable resource, got %v", got)
+	}
+
+	for i, r := range want {
+		if got[i] != r {
+			t.Fatalf("expected %v at index %d, got %v", r, i, got[i])
+		}
+	}
+}
+
+func TestResourcesOrDefault_ExplicitResourcesPassThrough(t *testing.T) {
+	req := WatchRequest{Namespace: "default", Resources: []flipt.Resource{flipt.ResourceFlag}}
+
+	got := req.ResourcesOrDefault()
+
+	if len(got) != 1 || got[0] != flipt.ResourceFlag {
+		t.Fatalf("expected the explicit resource list to pass through unchanged, got %v", got)
+	}
+}
@@ -0,0 +1,266 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.flipt.io/flipt/internal/analytics"
+	"go.flipt.io/flipt/internal/config"
+	rpcanalytics "go.flipt.io/flipt/rpc/flipt/analytics"
+	"go.uber.org/zap"
+)
+
+const idFileName = "telemetry_id"
+
+// Snapshot is the anonymized payload reported to config.Telemetry.Endpoint. It intentionally
+// carries only counts and enum distributions: nothing here can identify a flag key, segment key,
+// or end user.
+type Snapshot struct {
+	InstanceID string    `json:"instance_id"`
+	Version    string    `json:"version"`
+	OS         string    `json:"os"`
+	Arch       string    `json:"arch"`
+	DBDialect  string    `json:"db_dialect"`
+	ReportedAt time.Time `json:"reported_at"`
+
+	Namespaces []NamespaceStats `json:"namespaces"`
+}
+
+// NamespaceStats is the per-namespace count and enum distribution rolled into a Snapshot. It does
+// not carry the namespace key.
+type NamespaceStats struct {
+	Flags     int            `json:"flags"`
+	Segments  int            `json:"segments"`
+	Rules     int            `json:"rules"`
+	Rollouts  int            `json:"rollouts"`
+	FlagTypes map[string]int `json:"flag_types"`
+
+	// Evaluations is the rolled-up evaluation count observed over the reporting interval.
+	Evaluations float32 `json:"evaluations"`
+}
+
+// Store is the subset of storage Flipt's telemetry reporter needs to count resources. It is
+// satisfied by the regular storage.Store used to serve the API.
+type Store interface {
+	CountNamespaces(ctx context.Context) (int, error)
+	CountFlags(ctx context.Context, namespace string) (int, map[string]int, error)
+	CountSegments(ctx context.Context, namespace string) (int, error)
+	CountRules(ctx context.Context, namespace string) (int, error)
+	CountRollouts(ctx context.Context, namespace string) (int, error)
+	ListNamespaceKeys(ctx context.Context) ([]string, error)
+	ListFlagKeys(ctx context.Context, namespace string) ([]string, error)
+}
+
+// Reporter periodically builds a Snapshot and POSTs it to the configured endpoint. It is a
+// no-op unless config.Telemetry.Enabled is set.
+type Reporter struct {
+	logger *zap.Logger
+	cfg    *config.Config
+	store  Store
+	sink   analytics.Sink
+
+	instanceID string
+	http       *http.Client
+}
+
+// NewReporter constructs a Reporter, persisting (or loading) the instance UUID under dataDir on
+// first use.
+func NewReporter(logger *zap.Logger, cfg *config.Config, store Store, sink analytics.Sink, dataDir string) (*Reporter, error) {
+	id, err := loadOrCreateInstanceID(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: %w", err)
+	}
+
+	return &Reporter{
+		logger:     logger,
+		cfg:        cfg,
+		store:      store,
+		sink:       sink,
+		instanceID: id,
+		http:       &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// loadOrCreateInstanceID reads the persisted instance UUID from dataDir, generating and
+// persisting one if it doesn't exist yet.
+func loadOrCreateInstanceID(dataDir string) (string, error) {
+	path := filepath.Join(dataDir, idFileName)
+
+	if contents, err := os.ReadFile(path); err == nil {
+		return strings.TrimSpace(string(contents)), nil
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	id := uuid.NewString()
+	if err := os.WriteFile(path, []byte(id), 0o600); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Run blocks, reporting a Snapshot every config.Telemetry.Interval until ctx is cancelled.
+// Reporting failures are logged and never propagated: telemetry must never affect the request
+// path.
+func (r *Reporter) Run(ctx context.Context) {
+	if !r.cfg.Telemetry.Enabled {
+		return
+	}
+
+	ticker := time.NewTicker(r.cfg.Telemetry.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.report(ctx); err != nil {
+				r.logger.Debug("failed to report telemetry", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (r *Reporter) report(ctx context.Context) error {
+	snapshot, err := r.Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.cfg.Telemetry.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry: endpoint returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Build assembles the Snapshot that would be (or was) reported, without sending it. It is also
+// used by the `flipt telemetry preview` CLI command so operators can audit the exact payload
+// before enabling reporting.
+func (r *Reporter) Build(ctx context.Context) (Snapshot, error) {
+	namespaceKeys, err := r.store.ListNamespaceKeys(ctx)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		InstanceID: r.instanceID,
+		Version:    config.Version,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		DBDialect:  string(r.cfg.Database.Protocol),
+		ReportedAt: time.Now().UTC(),
+		Namespaces: make([]NamespaceStats, 0, len(namespaceKeys)),
+	}
+
+	for _, ns := range namespaceKeys {
+		flags, flagTypes, err := r.store.CountFlags(ctx, ns)
+		if err != nil {
+			return Snapshot{}, err
+		}
+
+		segments, err := r.store.CountSegments(ctx, ns)
+		if err != nil {
+			return Snapshot{}, err
+		}
+
+		rules, err := r.store.CountRules(ctx, ns)
+		if err != nil {
+			return Snapshot{}, err
+		}
+
+		rollouts, err := r.store.CountRollouts(ctx, ns)
+		if err != nil {
+			return Snapshot{}, err
+		}
+
+		stats := NamespaceStats{
+			Flags:     flags,
+			Segments:  segments,
+			Rules:     rules,
+			Rollouts:  rollouts,
+			FlagTypes: flagTypes,
+		}
+
+		if r.sink != nil {
+			total, err := r.evaluationsForNamespace(ctx, ns)
+			if err == nil {
+				stats.Evaluations = total
+			} else {
+				r.logger.Debug("failed to roll up evaluation counts for namespace", zap.String("namespace", ns), zap.Error(err))
+			}
+		}
+
+		snapshot.Namespaces = append(snapshot.Namespaces, stats)
+	}
+
+	return snapshot, nil
+}
+
+// evaluationsForNamespace sums the evaluation count, over the reporting interval, across every
+// flag in ns. GetFlagEvaluationsCount is scoped to a single flag key, so a namespace-wide rollup
+// requires querying it once per flag rather than once with an empty flag key.
+func (r *Reporter) evaluationsForNamespace(ctx context.Context, ns string) (float32, error) {
+	flagKeys, err := r.store.ListFlagKeys(ctx, ns)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float32
+	for _, flagKey := range flagKeys {
+		_, values, err := r.sink.GetFlagEvaluationsCount(ctx, r.evaluationWindowRequest(ns, flagKey))
+		if err != nil {
+			return 0, err
+		}
+
+		for _, v := range values {
+			total += v
+		}
+	}
+
+	return total, nil
+}
+
+// evaluationWindowRequest builds the request used to roll up evaluation counts for a single flag
+// over the reporting interval.
+func (r *Reporter) evaluationWindowRequest(ns, flagKey string) *rpcanalytics.GetFlagEvaluationsCountRequest {
+	now := time.Now().UTC()
+
+	return &rpcanalytics.GetFlagEvaluationsCountRequest{
+		NamespaceKey: ns,
+		FlagKey:      flagKey,
+		From:         now.Add(-r.cfg.Telemetry.Interval).Format("2006-01-02 15:04:05"),
+		To:           now.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,75 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.flipt.io/flipt/internal/analytics"
+	"go.flipt.io/flipt/internal/config"
+	rpcanalytics "go.flipt.io/flipt/rpc/flipt/analytics"
+	"go.uber.org/zap"
+)
+
+// fakeStore implements Store; only ListFlagKeys, which evaluationsForNamespace uses, returns data.
+type fakeStore struct {
+	flagKeys []string
+}
+
+func (s *fakeStore) CountNamespaces(ctx context.Context) (int, error) { return 0, nil }
+func (s *fakeStore) CountFlags(ctx context.Context, namespace string) (int, map[string]int, error) {
+	return 0, nil, nil
+}
+func (s *fakeStore) CountSegments(ctx context.Context, namespace string) (int, error) { return 0, nil }
+func (s *fakeStore) CountRules(ctx context.Context, namespace string) (int, error)    { return 0, nil }
+func (s *fakeStore) CountRollouts(ctx context.Context, namespace string) (int, error) { return 0, nil }
+func (s *fakeStore) ListNamespaceKeys(ctx context.Context) ([]string, error)          { return nil, nil }
+func (s *fakeStore) ListFlagKeys(ctx context.Context, namespace string) ([]string, error) {
+	return s.flagKeys, nil
+}
+
+// fakeSink records the flag key each GetFlagEvaluationsCount call was scoped to and returns a
+// fixed count per flag, so the test can assert the rollup sums across every flag key instead of
+// issuing a single empty-FlagKey query that every real Sink would filter to zero.
+type fakeSink struct {
+	countsByFlag map[string]float32
+	seenFlagKeys []string
+}
+
+func (s *fakeSink) IncrementFlagEvaluation(ctx context.Context, namespaceKey, flagKey string) error {
+	return nil
+}
+func (s *fakeSink) RecordEvaluation(ctx context.Context, event analytics.EvaluationEvent) error {
+	return nil
+}
+func (s *fakeSink) GetFlagEvaluationsCount(ctx context.Context, req *rpcanalytics.GetFlagEvaluationsCountRequest) ([]string, []float32, error) {
+	s.seenFlagKeys = append(s.seenFlagKeys, req.FlagKey)
+	if req.FlagKey == "" {
+		return nil, nil, nil
+	}
+	return []string{"t"}, []float32{s.countsByFlag[req.FlagKey]}, nil
+}
+func (s *fakeSink) Close() error { return nil }
+
+func TestEvaluationsForNamespace_SumsAcrossFlagKeys(t *testing.T) {
+	store := &fakeStore{flagKeys: []string{"flag-a", "flag-b"}}
+	sink := &fakeSink{countsByFlag: map[string]float32{"flag-a": 3, "flag-b": 7}}
+
+	cfg := &config.Config{Telemetry: config.TelemetryConfig{Interval: time.Hour}}
+	r := &Reporter{logger: zap.NewNop(), cfg: cfg, store: store, sink: sink}
+
+	total, err := r.evaluationsForNamespace(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if total != 10 {
+		t.Fatalf("expected the per-flag counts to be summed (3+7=10), got %v", total)
+	}
+
+	for _, flagKey := range sink.seenFlagKeys {
+		if flagKey == "" {
+			t.Fatal("GetFlagEvaluationsCount was called with an empty FlagKey, which every real Sink filters to zero results")
+		}
+	}
+}
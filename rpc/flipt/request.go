@@ -17,6 +17,8 @@ const (
 	ResourceNamespace      Resource = "namespace"
 	ResourceFlag           Resource = "flag"
 	ResourceSegment        Resource = "segment"
+	ResourceRule           Resource = "rule"
+	ResourceRollout        Resource = "rollout"
 	ResourceAuthentication Resource = "authentication"
 
 	SubjectConstraint   Subject = "constraint"
@@ -0,0 +1,39 @@
+package flipt
+
+import "google.golang.org/grpc"
+
+// The types below are hand-written stand-ins for what `buf generate` would produce from
+// flipt.proto's new `Watch` rpc (see that file). They are not wired into FliptServer,
+// _Flipt_serviceDesc, or RegisterFliptServer, so Flipt.Watch cannot yet be invoked by a real gRPC
+// client — regenerating from flipt.proto is required before this RPC is actually reachable.
+//
+// TODO: blocking follow-up — run `buf generate` against flipt.proto's Watch rpc and replace these
+// stand-ins with the generated types before considering "expose Watch as a gRPC RPC" done.
+
+// WatchRequest scopes a Flipt.Watch subscription to a namespace and, optionally, a subset of
+// resource kinds. An empty Resources list subscribes to every watchable resource.
+type WatchRequest struct {
+	NamespaceKey string   `json:"namespaceKey"`
+	Resources    []string `json:"resources,omitempty"`
+}
+
+func (req *WatchRequest) Request() Request {
+	return NewRequest(ResourceNamespace, ActionRead, WithNamespace(req.NamespaceKey))
+}
+
+// ChangeEvent describes a single change to a flag, segment, rule, or rollout, streamed to
+// subscribers of Flipt.Watch.
+type ChangeEvent struct {
+	Namespace string `json:"namespace"`
+	Resource  string `json:"resource"`
+	Subject   string `json:"subject"`
+	Action    string `json:"action"`
+	Key       string `json:"key"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Flipt_WatchServer is the server-side stream handle for the Flipt.Watch RPC.
+type Flipt_WatchServer interface {
+	Send(*ChangeEvent) error
+	grpc.ServerStream
+}